@@ -1,22 +1,46 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"time"
 
 	tsp_solver "github.com/ivynya/EEC289Q/pkg"
+	"github.com/ivynya/EEC289Q/pkg/encoding"
+	"github.com/ivynya/EEC289Q/pkg/encoding/dot"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-func loadGraph(inputFile string) (*tsp_solver.Graph, error) {
+// loadGraph reads inputFile and builds a *tsp_solver.Graph from it. It
+// dispatches on the file extension for DOT/Graphviz input (.dot, .gv),
+// autodetects graph6/sparse6 payloads by their magic prefix, and otherwise
+// falls back to the solver's custom "n / m / from to weight" text format.
+// The returned map is non-nil only for DOT input, where it maps internal
+// node indices back to their original DOT identifiers.
+func loadGraph(inputFile string, weight encoding.WeightFunc) (*tsp_solver.Graph, map[int]string, error) {
 	data, err := os.ReadFile(inputFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	switch filepath.Ext(inputFile) {
+	case ".dot", ".gv":
+		graph, labels, err := dot.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		return graph, labels, nil
+	}
+
+	if encoding.Sniff(data) {
+		graph, err := encoding.Decode(data, weight)
+		return graph, nil, err
 	}
 
 	// split file content by newlines
@@ -36,7 +60,7 @@ func loadGraph(inputFile string) (*tsp_solver.Graph, error) {
 	nodeCountClaim, err := strconv.ParseInt(lines[0], 10, 64)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Error parsing node count")
-		return nil, err
+		return nil, nil, err
 	}
 
 	// build adjacency map for the graph: graph[from][to] = weight
@@ -67,7 +91,7 @@ func loadGraph(inputFile string) (*tsp_solver.Graph, error) {
 		log.Warn().Msgf("Warning: claimed node count %d mismatch actual %d", nodeCountClaim, graph.NodeCount())
 	}
 
-	return graph, nil
+	return graph, nil, nil
 }
 
 func main() {
@@ -89,14 +113,56 @@ func main() {
 	// get flags from input
 	cpuFlag := flag.Int("cpu", -1, "Max CPU to use (default=-1 : all)")
 	timeFlag := flag.Int("time", 59, "Time limit in seconds (default=59)")
+	weightFlag := flag.String("weight", "unit", "edge weight mode for unweighted inputs like graph6/sparse6 (unit|hamming|coords)")
+	coordsFlag := flag.String("coords", "", "path to a companion coordinate file for -weight=coords")
+	policyFlag := flag.String("policy", "greedy", "initial tour construction policy to benchmark (greedy|dijkstra)")
+	seedFlag := flag.String("seed", "nn", "initial tour seed(s) to consider (nn|christofides|both)")
 	flag.Parse()
 	if *cpuFlag <= 0 {
 		*cpuFlag = runtime.NumCPU()
 	}
 
+	weightMode, err := encoding.ParseWeightMode(*weightFlag)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid -weight flag")
+		return
+	}
+
+	policy, err := tsp_solver.NewPolicy(*policyFlag, 3)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid -policy flag")
+		return
+	}
+
+	seedMode, err := tsp_solver.ParseSeedMode(*seedFlag)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid -seed flag")
+		return
+	}
+
+	var coords []encoding.Point
+	if *coordsFlag != "" {
+		coordsData, err := os.ReadFile(*coordsFlag)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read coordinate file")
+			return
+		}
+		coords, err = encoding.ParseCoords(coordsData)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to parse coordinate file")
+			return
+		}
+	}
+
+	weight, err := encoding.NewWeightFunc(weightMode, coords)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to build weight function")
+		return
+	}
+
 	// read input file name to graph struct
 	inputFile := flag.Args()[0]
-	graph, err := loadGraph(inputFile)
+	graph, labels, err := loadGraph(inputFile, weight)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load graph from file")
 		return
@@ -108,6 +174,14 @@ func main() {
 
 	// solve TSP and print result
 	log.Info().Msgf("Using %d CPUs and %d seconds time limit", *cpuFlag, *timeFlag)
-	path, dist, cycles := tsp_solver.SolveTSP(graph, *cpuFlag, *timeFlag)
+	path, dist, cycles := tsp_solver.SolveTSP(graph, *cpuFlag, *timeFlag, policy, seedMode)
+	if labels != nil {
+		named := make([]string, len(path))
+		for i, node := range path {
+			named[i] = labels[node]
+		}
+		log.Info().Msgf("Best path found with cost %.4f (visited %d cycles): %v", dist, cycles, named)
+		return
+	}
 	log.Info().Msgf("Best path found with cost %.4f (visited %d cycles): %v", dist, cycles, path)
 }
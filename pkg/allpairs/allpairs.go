@@ -0,0 +1,104 @@
+// Package allpairs computes the metric closure of a weighted graph via
+// Floyd-Warshall, so that solvers which assume a complete graph (like
+// 2-Opt) can still run on sparse or incomplete instances.
+package allpairs
+
+import "math"
+
+// Inf represents an unreachable pair of nodes in a MetricClosure.
+const Inf = math.MaxFloat64
+
+// NeighborGraph is the minimal view of a graph that Build needs. It is
+// satisfied by *tsp_solver.Graph without allpairs importing that package.
+type NeighborGraph interface {
+	Nodes() []int
+	Neighbors(node int) map[int]float64
+}
+
+// MetricClosure answers all-pairs shortest-path distance and path queries,
+// precomputed once via Floyd-Warshall (O(V^3) time, O(V^2) memory, using
+// dense []float64/[]int matrices rather than nested maps).
+type MetricClosure struct {
+	nodes []int
+	index map[int]int
+	dist  []float64 // n*n: dist[i*n+j]
+	next  []int     // n*n: next[i*n+j] = index of the next hop from i towards j, or -1
+}
+
+// Build runs Floyd-Warshall over graph and returns the resulting
+// MetricClosure. Callers should run this once, after loading the graph,
+// whenever it is not known to be complete.
+func Build(graph NeighborGraph) *MetricClosure {
+	nodes := graph.Nodes()
+	n := len(nodes)
+
+	index := make(map[int]int, n)
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	dist := make([]float64, n*n)
+	next := make([]int, n*n)
+	for i := range dist {
+		dist[i] = Inf
+		next[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		dist[i*n+i] = 0
+	}
+
+	for _, u := range nodes {
+		ui := index[u]
+		for v, w := range graph.Neighbors(u) {
+			vi := index[v]
+			dist[ui*n+vi] = w
+			next[ui*n+vi] = vi
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			dik := dist[i*n+k]
+			if dik == Inf {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				alt := dik + dist[k*n+j]
+				if alt < dist[i*n+j] {
+					dist[i*n+j] = alt
+					next[i*n+j] = next[i*n+k]
+				}
+			}
+		}
+	}
+
+	return &MetricClosure{nodes: nodes, index: index, dist: dist, next: next}
+}
+
+// Dist returns the shortest-path distance between nodes u and v, or Inf if
+// v is unreachable from u.
+func (m *MetricClosure) Dist(u, v int) float64 {
+	n := len(m.nodes)
+	return m.dist[m.index[u]*n+m.index[v]]
+}
+
+// Path returns the sequence of nodes on a shortest path from u to v,
+// excluding u itself but including v, or nil if v is unreachable from u.
+func (m *MetricClosure) Path(u, v int) []int {
+	n := len(m.nodes)
+	ui, vi := m.index[u], m.index[v]
+	if m.dist[ui*n+vi] == Inf {
+		return nil
+	}
+
+	path := make([]int, 0, 4)
+	cur := ui
+	for cur != vi {
+		cur = m.next[cur*n+vi]
+		if cur == -1 {
+			return nil
+		}
+		path = append(path, m.nodes[cur])
+	}
+	return path
+}
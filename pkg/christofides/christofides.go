@@ -0,0 +1,259 @@
+// Package christofides builds a deterministic Christofides-style
+// approximate TSP tour: a minimum spanning tree, a matching over the
+// tree's odd-degree vertices, an Eulerian circuit over their union, and a
+// shortcut pass down to a Hamiltonian tour. It is offered as an
+// alternative seed to the randomized nearest-neighbor policies in the
+// parent package, selected via -seed.
+package christofides
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/ivynya/EEC289Q/pkg/allpairs"
+	"github.com/ivynya/EEC289Q/pkg/pqueue"
+)
+
+// NeighborGraph is the minimal view of a graph that Build needs. It
+// mirrors allpairs.NeighborGraph so both packages accept *tsp_solver.Graph
+// directly without either importing tsp_solver.
+type NeighborGraph interface {
+	Nodes() []int
+	Neighbors(node int) map[int]float64
+}
+
+// Matcher computes a near-minimum-weight perfect matching over vertices,
+// given a function to look up the weight between any two of them.
+// greedyMatcher is Build's default; a full Blossom algorithm could satisfy
+// this interface later without Build's callers needing to change.
+type Matcher interface {
+	Match(vertices []int, weight func(u, v int) float64) [][2]int
+}
+
+// Build computes a Christofides-style tour over graph using greedyMatcher.
+// If closure is non-nil (graph is not complete), edges missing from graph
+// are costed via the metric closure, the same fallback twoOpt and orOpt
+// use for sparse graphs.
+func Build(graph NeighborGraph, closure *allpairs.MetricClosure) ([]int, float64) {
+	return BuildWithMatcher(graph, closure, greedyMatcher{})
+}
+
+// BuildWithMatcher is Build with an explicit Matcher, so a better matching
+// strategy can be swapped in at the odd-vertex-matching step.
+func BuildWithMatcher(graph NeighborGraph, closure *allpairs.MetricClosure, matcher Matcher) ([]int, float64) {
+	nodes := graph.Nodes()
+	if len(nodes) == 0 {
+		return []int{}, 0
+	}
+	if len(nodes) == 1 {
+		return []int{nodes[0]}, 0
+	}
+
+	mst := primMST(nodes, graph, closure)
+
+	var odd []int
+	for _, n := range nodes {
+		if len(mst[n])%2 == 1 {
+			odd = append(odd, n)
+		}
+	}
+
+	pairs := matcher.Match(odd, func(u, v int) float64 {
+		w, _ := edgeWeight(graph, closure, u, v)
+		return w
+	})
+
+	multigraph := make(map[int][]int, len(nodes))
+	for v, adj := range mst {
+		multigraph[v] = append([]int(nil), adj...)
+	}
+	for _, p := range pairs {
+		multigraph[p[0]] = append(multigraph[p[0]], p[1])
+		multigraph[p[1]] = append(multigraph[p[1]], p[0])
+	}
+
+	tour := shortcut(eulerianCircuit(multigraph, nodes[0]))
+
+	cost := 0.0
+	for i, v := range tour {
+		next := tour[(i+1)%len(tour)]
+		if w, ok := edgeWeight(graph, closure, v, next); ok {
+			cost += w
+		}
+	}
+
+	return tour, cost
+}
+
+// edgeWeight returns the weight of edge (u, v), preferring a direct graph
+// edge and falling back to the metric closure (if any) for missing edges.
+func edgeWeight(graph NeighborGraph, closure *allpairs.MetricClosure, u, v int) (float64, bool) {
+	if w, ok := graph.Neighbors(u)[v]; ok {
+		return w, true
+	}
+	if closure != nil {
+		return closure.Dist(u, v), true
+	}
+	return 0, false
+}
+
+// primMST builds a minimum spanning tree of graph (treated as complete
+// via closure when non-nil) using Prim's algorithm, with pkg/pqueue as
+// the decrease-key frontier for O(E log V) on graphs with direct edges
+// only. It returns the tree as an adjacency list keyed by node ID.
+func primMST(nodes []int, graph NeighborGraph, closure *allpairs.MetricClosure) map[int][]int {
+	mst := make(map[int][]int, len(nodes))
+	for _, n := range nodes {
+		mst[n] = nil
+	}
+	if len(nodes) < 2 {
+		return mst
+	}
+
+	// items holds each not-yet-settled node's queue entry, so it can be
+	// relaxed in place with Fix; a missing entry means the node has
+	// already been added to the tree.
+	items := make(map[int]*pqueue.Item, len(nodes))
+	attach := make(map[int]int, len(nodes))
+
+	pq := &pqueue.PriorityQueue{}
+	heap.Init(pq)
+	for i, n := range nodes {
+		priority := allpairs.Inf
+		if i == 0 {
+			priority = 0
+		}
+		item := &pqueue.Item{Value: n, Priority: priority}
+		items[n] = item
+		attach[n] = -1
+		heap.Push(pq, item)
+	}
+
+	relax := func(from, candidate int, w float64) {
+		item := items[candidate]
+		if item != nil && w < item.Priority {
+			item.Priority = w
+			attach[candidate] = from
+			pq.Fix(item)
+		}
+	}
+
+	for pq.Len() > 0 {
+		v := heap.Pop(pq).(*pqueue.Item).Value
+		delete(items, v)
+
+		if from := attach[v]; from != -1 {
+			mst[from] = append(mst[from], v)
+			mst[v] = append(mst[v], from)
+		}
+
+		if closure != nil {
+			for _, u := range nodes {
+				if u == v {
+					continue
+				}
+				if w, ok := edgeWeight(graph, closure, v, u); ok {
+					relax(v, u, w)
+				}
+			}
+		} else {
+			for u, w := range graph.Neighbors(v) {
+				relax(v, u, w)
+			}
+		}
+	}
+
+	return mst
+}
+
+// greedyMatcher pairs vertices by repeatedly taking the cheapest
+// remaining candidate edge that doesn't reuse an already-matched vertex.
+// It is not minimum-weight in general, but is a cheap stand-in for a full
+// Blossom algorithm.
+type greedyMatcher struct{}
+
+func (greedyMatcher) Match(vertices []int, weight func(u, v int) float64) [][2]int {
+	type candidate struct {
+		u, v int
+		w    float64
+	}
+
+	candidates := make([]candidate, 0, len(vertices)*(len(vertices)-1)/2)
+	for i, u := range vertices {
+		for _, v := range vertices[i+1:] {
+			candidates = append(candidates, candidate{u, v, weight(u, v)})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].w < candidates[j].w })
+
+	matched := make(map[int]bool, len(vertices))
+	var pairs [][2]int
+	for _, c := range candidates {
+		if matched[c.u] || matched[c.v] {
+			continue
+		}
+		matched[c.u] = true
+		matched[c.v] = true
+		pairs = append(pairs, [2]int{c.u, c.v})
+	}
+	return pairs
+}
+
+// eulerianCircuit finds an Eulerian circuit over multigraph starting at
+// start, using Hierholzer's algorithm with an explicit stack instead of
+// recursion. Every vertex in multigraph must have even degree, which
+// holds here since each one gets one matching edge for every odd MST
+// degree it had.
+func eulerianCircuit(multigraph map[int][]int, start int) []int {
+	remaining := make(map[int][]int, len(multigraph))
+	for v, adj := range multigraph {
+		remaining[v] = append([]int(nil), adj...)
+	}
+
+	var circuit []int
+	stack := []int{start}
+
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		adj := remaining[v]
+		if len(adj) == 0 {
+			circuit = append(circuit, v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		u := adj[len(adj)-1]
+		remaining[v] = adj[:len(adj)-1]
+		remaining[u] = removeOne(remaining[u], v)
+		stack = append(stack, u)
+	}
+
+	return circuit
+}
+
+// removeOne removes the first occurrence of node from list, used to
+// consume one direction of an undirected multigraph edge at a time.
+func removeOne(list []int, node int) []int {
+	for i, n := range list {
+		if n == node {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+// shortcut walks circuit, an Eulerian circuit that may repeat vertices,
+// and keeps only each vertex's first occurrence -- the shortcutting step
+// that turns it into a Hamiltonian tour.
+func shortcut(circuit []int) []int {
+	seen := make(map[int]bool, len(circuit))
+	tour := make([]int, 0, len(circuit))
+	for _, v := range circuit {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		tour = append(tour, v)
+	}
+	return tour
+}
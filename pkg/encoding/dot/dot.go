@@ -0,0 +1,89 @@
+// Package dot provides a minimal line-oriented parser for the DOT/Graphviz
+// text format, producing a *tsp_solver.Graph from a weighted "graph" or
+// "digraph" description.
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+
+	tsp_solver "github.com/ivynya/EEC289Q/pkg"
+)
+
+// nodeRe matches a node declaration line, e.g. `"a" [label="City A"];`.
+var nodeRe = regexp.MustCompile(`^\s*"?([\w.-]+)"?\s*\[([^\]]*)\]\s*;?\s*$`)
+
+// edgeRe matches an edge line, e.g. `"a" -- "b" [weight=2.5];` or
+// `"a" -> "b";`.
+var edgeRe = regexp.MustCompile(`^\s*"?([\w.-]+)"?\s*(?:--|->)\s*"?([\w.-]+)"?\s*(?:\[([^\]]*)\])?\s*;?\s*$`)
+
+// weightRe extracts a weight= attribute from a bracketed attribute list.
+var weightRe = regexp.MustCompile(`weight\s*=\s*"?(-?[0-9.]+)"?`)
+
+// Decode reads a DOT/Graphviz "graph"/"digraph" description from r and
+// builds a *tsp_solver.Graph from its node and edge lines. Edges without an
+// explicit weight= attribute default to weight 1. It returns the graph
+// alongside a mapping from each internal node index back to its original
+// DOT identifier, so a solved tour can be printed with the original names.
+func Decode(r io.Reader) (*tsp_solver.Graph, map[int]string, error) {
+	ids := make(map[string]int)
+	labels := make(map[int]string)
+
+	intern := func(name string) int {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		id := len(ids)
+		ids[name] = id
+		labels[id] = name
+		return id
+	}
+
+	var edges []struct {
+		from, to int
+		weight   float64
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := edgeRe.FindStringSubmatch(line); m != nil {
+			from := intern(m[1])
+			to := intern(m[2])
+			weight := 1.0
+			if wm := weightRe.FindStringSubmatch(m[3]); wm != nil {
+				w, err := strconv.ParseFloat(wm[1], 64)
+				if err != nil {
+					return nil, nil, fmt.Errorf("dot: invalid weight on edge %q -- %q: %w", m[1], m[2], err)
+				}
+				weight = w
+			}
+			edges = append(edges, struct {
+				from, to int
+				weight   float64
+			}{from, to, weight})
+			continue
+		}
+
+		if m := nodeRe.FindStringSubmatch(line); m != nil {
+			intern(m[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("dot: %w", err)
+	}
+
+	graph := tsp_solver.NewGraph(len(ids))
+	for i := range labels {
+		graph.AddNode(i)
+	}
+	for _, e := range edges {
+		graph.AddEdge(e.from, e.to, e.weight)
+	}
+
+	return graph, labels, nil
+}
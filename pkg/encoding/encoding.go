@@ -0,0 +1,183 @@
+// Package encoding decodes external ASCII graph interchange formats
+// (graph6, sparse6) into a *tsp_solver.Graph, complementing the solver's own
+// custom "n / m / from to weight" text format.
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+
+	tsp_solver "github.com/ivynya/EEC289Q/pkg"
+)
+
+const (
+	graph6Prefix  = ">>graph6<<"
+	sparse6Prefix = ">>sparse6<<"
+)
+
+// WeightFunc assigns a weight to an edge discovered while decoding an
+// unweighted format. It is derived from the -weight CLI flag. It returns
+// an error if u or v cannot be priced, e.g. a WeightCoords function asked
+// about a node index beyond its coordinate file.
+type WeightFunc func(u, v int) (float64, error)
+
+// WeightMode selects how edge weights are synthesized for formats (graph6,
+// sparse6) that do not carry weights themselves.
+type WeightMode string
+
+const (
+	WeightUnit    WeightMode = "unit"
+	WeightHamming WeightMode = "hamming"
+	WeightCoords  WeightMode = "coords"
+)
+
+// Point is a 2D coordinate used to derive Euclidean edge weights in
+// WeightCoords mode.
+type Point struct {
+	X, Y float64
+}
+
+// NewWeightFunc builds a WeightFunc for the given mode. coords is only
+// consulted in WeightCoords mode and maps a node index to its position;
+// it must have at least as many entries as the graph has nodes.
+func NewWeightFunc(mode WeightMode, coords []Point) (WeightFunc, error) {
+	switch mode {
+	case "", WeightUnit:
+		return func(u, v int) (float64, error) { return 1, nil }, nil
+	case WeightHamming:
+		return func(u, v int) (float64, error) { return float64(bits.OnesCount(uint(u ^ v))), nil }, nil
+	case WeightCoords:
+		if coords == nil {
+			return nil, fmt.Errorf("weight mode %q requires a coordinate file", mode)
+		}
+		return func(u, v int) (float64, error) {
+			if u < 0 || u >= len(coords) || v < 0 || v >= len(coords) {
+				return 0, fmt.Errorf("node index out of range for coordinate file (have %d coordinates)", len(coords))
+			}
+			dx := coords[u].X - coords[v].X
+			dy := coords[u].Y - coords[v].Y
+			return math.Sqrt(dx*dx + dy*dy), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown weight mode %q", mode)
+	}
+}
+
+// ParseCoords reads a companion coordinate file with one "x y" pair per
+// line. Blank lines are skipped, so the i-th non-blank line (0-based) gives
+// node ID i's position.
+func ParseCoords(data []byte) ([]Point, error) {
+	var coords []Point
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var x, y float64
+		if _, err := fmt.Sscanf(line, "%f %f", &x, &y); err != nil {
+			return nil, fmt.Errorf("coords: line %d: %w", i, err)
+		}
+		coords = append(coords, Point{X: x, Y: y})
+	}
+	return coords, nil
+}
+
+// Sniff reports whether data looks like a graph6 or sparse6 payload, so
+// callers can dispatch before falling back to another format.
+func Sniff(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(graph6Prefix)), bytes.HasPrefix(trimmed, []byte(sparse6Prefix)):
+		return true
+	case len(trimmed) > 0 && trimmed[0] == ':':
+		return true
+	case len(trimmed) > 0 && trimmed[0] >= 63 && trimmed[0] <= 126:
+		return true
+	default:
+		return false
+	}
+}
+
+// Decode autodetects the graph6/sparse6 encoding of data (by its
+// ">>graph6<<"/">>sparse6<<" header or leading ':' marker) and decodes it
+// into a *tsp_solver.Graph.
+func Decode(data []byte, weight WeightFunc) (*tsp_solver.Graph, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(sparse6Prefix)):
+		return DecodeSparse6(data, weight)
+	case bytes.HasPrefix(trimmed, []byte(graph6Prefix)):
+		return DecodeGraph6(data, weight)
+	case len(trimmed) > 0 && trimmed[0] == ':':
+		return DecodeSparse6(data, weight)
+	case len(trimmed) > 0 && trimmed[0] >= 63 && trimmed[0] <= 126:
+		return DecodeGraph6(data, weight)
+	default:
+		return nil, fmt.Errorf("encoding: unrecognized graph6/sparse6 input")
+	}
+}
+
+// decodeN parses the leading N(n) field shared by graph6 and sparse6: a
+// single byte (value-63) for n <= 62, or byte 126 followed by a 3-byte,
+// 18-bit big-endian extension for larger n. It returns n and the
+// unconsumed remainder of data.
+func decodeN(data []byte) (int, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("empty N(n) field")
+	}
+	if data[0] == 126 {
+		if len(data) < 4 {
+			return 0, nil, fmt.Errorf("truncated extended N(n) field")
+		}
+		n := bitsToInt(unpackBits(data[1:4]))
+		return n, data[4:], nil
+	}
+	n := int(data[0]) - 63
+	if n < 0 {
+		return 0, nil, fmt.Errorf("invalid N(n) byte %d", data[0])
+	}
+	return n, data[1:], nil
+}
+
+// unpackBits expands each byte (value-63) of a graph6/sparse6 body into
+// its 6 constituent bits, most-significant bit first.
+func unpackBits(data []byte) []byte {
+	bitVals := make([]byte, 0, len(data)*6)
+	for _, c := range data {
+		v := c - 63
+		for shift := 5; shift >= 0; shift-- {
+			bitVals = append(bitVals, (v>>uint(shift))&1)
+		}
+	}
+	return bitVals
+}
+
+func bitsToInt(bitVals []byte) int {
+	n := 0
+	for _, b := range bitVals {
+		n = (n << 1) | int(b)
+	}
+	return n
+}
+
+// bitsNeeded returns ceil(log2(n)), the width of the vertex field used by
+// sparse6, with the convention that a 1-vertex graph still uses 1 bit.
+func bitsNeeded(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return bits.Len(uint(n - 1))
+}
+
+// ParseWeightMode validates a -weight flag value.
+func ParseWeightMode(s string) (WeightMode, error) {
+	switch WeightMode(s) {
+	case WeightUnit, WeightHamming, WeightCoords:
+		return WeightMode(s), nil
+	default:
+		return "", fmt.Errorf("unknown weight mode %q (want unit, hamming, or coords)", s)
+	}
+}
@@ -0,0 +1,49 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+
+	tsp_solver "github.com/ivynya/EEC289Q/pkg"
+)
+
+// DecodeGraph6 parses a graph6-encoded byte slice (with an optional
+// ">>graph6<<" prefix) into a *tsp_solver.Graph. graph6 stores an undirected
+// simple graph as N(n) followed by the upper triangle of the adjacency
+// matrix, column by column; since the format carries no weights, weight is
+// called for every discovered edge to produce one.
+func DecodeGraph6(data []byte, weight WeightFunc) (*tsp_solver.Graph, error) {
+	body := bytes.TrimPrefix(bytes.TrimSpace(data), []byte(graph6Prefix))
+
+	n, rest, err := decodeN(body)
+	if err != nil {
+		return nil, fmt.Errorf("graph6: %w", err)
+	}
+
+	bitVals := unpackBits(rest)
+	needed := n * (n - 1) / 2
+	if len(bitVals) < needed {
+		return nil, fmt.Errorf("graph6: truncated adjacency data: need %d bits, got %d", needed, len(bitVals))
+	}
+
+	graph := tsp_solver.NewGraph(n)
+	for i := 0; i < n; i++ {
+		graph.AddNode(i)
+	}
+
+	idx := 0
+	for j := 1; j < n; j++ {
+		for i := 0; i < j; i++ {
+			if bitVals[idx] == 1 {
+				w, err := weight(i, j)
+				if err != nil {
+					return nil, fmt.Errorf("graph6: %w", err)
+				}
+				graph.AddEdge(i, j, w)
+			}
+			idx++
+		}
+	}
+
+	return graph, nil
+}
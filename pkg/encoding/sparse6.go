@@ -0,0 +1,59 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+
+	tsp_solver "github.com/ivynya/EEC289Q/pkg"
+)
+
+// DecodeSparse6 parses a sparse6-encoded byte slice (with an optional
+// ">>sparse6<<" prefix and mandatory leading ':' marker) into a
+// *tsp_solver.Graph. sparse6 stores an edge list as a bit stream of
+// (b, x) units: b is a 1-bit "increment current vertex" flag and x is a
+// k = ceil(log2 n) bit target vertex; weight is called for every
+// discovered edge to produce a weight for it.
+func DecodeSparse6(data []byte, weight WeightFunc) (*tsp_solver.Graph, error) {
+	body := bytes.TrimPrefix(bytes.TrimSpace(data), []byte(sparse6Prefix))
+	if len(body) == 0 || body[0] != ':' {
+		return nil, fmt.Errorf("sparse6: missing ':' marker")
+	}
+	body = body[1:]
+
+	n, rest, err := decodeN(body)
+	if err != nil {
+		return nil, fmt.Errorf("sparse6: %w", err)
+	}
+
+	k := bitsNeeded(n)
+	bitVals := unpackBits(rest)
+
+	graph := tsp_solver.NewGraph(n)
+	for i := 0; i < n; i++ {
+		graph.AddNode(i)
+	}
+
+	v := 0
+	for pos := 0; pos+1+k <= len(bitVals); pos += 1 + k {
+		b := bitVals[pos]
+		x := bitsToInt(bitVals[pos+1 : pos+1+k])
+
+		if b == 1 {
+			v++
+		}
+		if x > v {
+			v = x
+			continue
+		}
+		if v >= n {
+			break
+		}
+		w, err := weight(x, v)
+		if err != nil {
+			return nil, fmt.Errorf("sparse6: %w", err)
+		}
+		graph.AddEdge(x, v, w)
+	}
+
+	return graph, nil
+}
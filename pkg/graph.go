@@ -31,6 +31,16 @@ func (g *Graph) AddEdge(from, to int, weight float64) {
 	g.edges[t][f] = weight
 }
 
+// AddNode registers a node with no edges, so isolated vertices (as can
+// occur when decoding an incomplete graph) are still counted by NodeCount.
+// It is a no-op if the node already exists.
+func (g *Graph) AddNode(id int) {
+	if g.edges[id] == nil {
+		g.edges[id] = make(map[int]float64)
+		g.nodes = append(g.nodes, id)
+	}
+}
+
 func (g *Graph) NodeCount() int {
 	return len(g.nodes)
 }
@@ -42,3 +52,20 @@ func (g *Graph) EdgeCount() int {
 	}
 	return count
 }
+
+// Nodes returns the graph's node IDs.
+func (g *Graph) Nodes() []int {
+	return g.nodes
+}
+
+// Neighbors returns node's adjacency map (neighbor -> weight).
+func (g *Graph) Neighbors(node int) map[int]float64 {
+	return g.edges[node]
+}
+
+// IsComplete reports whether every pair of distinct nodes has a direct
+// edge, i.e. the graph already forms a complete metric.
+func (g *Graph) IsComplete() bool {
+	n := g.NodeCount()
+	return g.EdgeCount() == n*(n-1)
+}
@@ -0,0 +1,141 @@
+package tsp_solver
+
+import (
+	"context"
+	"math"
+
+	"github.com/ivynya/EEC289Q/pkg/allpairs"
+)
+
+const (
+	lkCandidates = 5
+	lkMaxDepth   = 5
+)
+
+// linKernighan performs a small Lin-Kernighan-style improvement pass. For
+// each tour edge (t1, t2) it greedily grows a chain t3, t4, ... by
+// repeatedly picking the best of the lkCandidates nearest unvisited
+// neighbors of the chain's free endpoint, applying the 2-opt-style
+// reversal that would close the chain back to t1, and continuing from the
+// new free endpoint -- so each step is guided by a small candidate list
+// instead of an exhaustive scan. A chain only extends while its running
+// gain stays positive, and the best-improving depth found (up to
+// lkMaxDepth) is kept.
+func linKernighan(path []int, currentCost float64, graph *Graph, ctx context.Context, closure *allpairs.MetricClosure) ([]int, float64) {
+	improved := true
+
+	for improved {
+		select {
+		case <-ctx.Done():
+			return path, currentCost
+		default:
+		}
+
+		improved = false
+		for i := 0; i < len(path); i++ {
+			select {
+			case <-ctx.Done():
+				return path, currentCost
+			default:
+			}
+
+			newPath, delta, ok := lkMove(path, i, graph, closure)
+			if ok {
+				path = newPath
+				currentCost += delta
+				improved = true
+				break // tour changed underneath the loop; restart the scan
+			}
+		}
+	}
+
+	return path, currentCost
+}
+
+// lkMove searches, from tour position i, for an improving chain of
+// sequential 2-opt-style reversals as described on linKernighan. It
+// returns the best-improving depth's tour and cost delta, if any.
+func lkMove(path []int, i int, graph *Graph, closure *allpairs.MetricClosure) ([]int, float64, bool) {
+	size := len(path)
+	if i >= size-1 {
+		return nil, 0, false // skip the wrap-around edge, as twoOpt does
+	}
+
+	trial := append([]int(nil), path...)
+	visited := map[int]bool{trial[i]: true}
+
+	pos := i
+	runningDelta := 0.0
+	bestDelta := 0.0
+	var bestTrial []int
+
+	for depth := 1; depth <= lkMaxDepth; depth++ {
+		t1 := trial[pos]
+		t2 := trial[pos+1]
+		w12, ok := edgeWeight(graph, closure, t1, t2)
+		if !ok {
+			break
+		}
+		visited[t2] = true
+
+		candidates := nearestUnvisited(t2, graph, visited, closure, lkCandidates)
+		if len(candidates) == 0 {
+			break
+		}
+
+		bestGain := math.Inf(-1)
+		bestJ := -1
+		var bestT3 int
+		for _, c := range candidates {
+			j := indexOf(trial, c.Value)
+			if j <= pos+1 || j >= size-1 {
+				continue // keep the reversal non-wrapping, like twoOpt
+			}
+			gain := w12 - c.Priority
+			if gain > bestGain {
+				bestGain = gain
+				bestJ = j
+				bestT3 = c.Value
+			}
+		}
+		if bestJ < 0 || bestGain <= 0 {
+			break // running-gain constraint violated
+		}
+
+		t3, j := bestT3, bestJ
+		t4 := trial[j+1]
+
+		w34, ok := edgeWeight(graph, closure, t3, t4)
+		wNew1, ok1 := edgeWeight(graph, closure, t1, t3)
+		wNew2, ok2 := edgeWeight(graph, closure, t2, t4)
+		if !ok || !ok1 || !ok2 {
+			break
+		}
+
+		reverse(trial, pos+1, j)
+		runningDelta += (wNew1 + wNew2) - (w12 + w34)
+
+		if runningDelta < bestDelta-1e-9 {
+			bestDelta = runningDelta
+			bestTrial = append([]int(nil), trial...)
+		}
+
+		visited[t3] = true
+		pos = j // t2 now sits here, adjacent to t4, ready for the next link
+	}
+
+	if bestTrial == nil {
+		return nil, 0, false
+	}
+	return bestTrial, bestDelta, true
+}
+
+// indexOf returns the index of node in path, or -1 if absent.
+func indexOf(path []int, node int) int {
+	for idx, n := range path {
+		if n == node {
+			return idx
+		}
+	}
+	return -1
+}
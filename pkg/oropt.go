@@ -0,0 +1,103 @@
+package tsp_solver
+
+import (
+	"context"
+
+	"github.com/ivynya/EEC289Q/pkg/allpairs"
+)
+
+// orOpt improves the path by relocating short contiguous segments (of
+// length 1, 2, or 3) to a better insertion point elsewhere in the tour.
+// Like twoOpt, missing direct edges are costed via closure (if non-nil) so
+// it still runs over a complete metric on non-complete graphs.
+func orOpt(path []int, currentCost float64, graph *Graph, ctx context.Context, closure *allpairs.MetricClosure) ([]int, float64) {
+	size := len(path)
+	improved := true
+
+	for improved {
+		select {
+		case <-ctx.Done():
+			return path, currentCost
+		default:
+		}
+
+		improved = false
+		for segLen := 1; segLen <= 3 && segLen < size-2; segLen++ {
+			// i ranges over non-wrapping segment starts with both a
+			// predecessor and a successor inside the slice, mirroring
+			// twoOpt's own skip of the wrap-around pair.
+			for i := 1; i+segLen < size; i++ {
+				prev := path[i-1]
+				segStart := path[i]
+				segEnd := path[i+segLen-1]
+				next := path[i+segLen]
+
+				wPrevSeg, ok1 := edgeWeight(graph, closure, prev, segStart)
+				wSegNext, ok2 := edgeWeight(graph, closure, segEnd, next)
+				wBridge, ok3 := edgeWeight(graph, closure, prev, next)
+				if !ok1 || !ok2 || !ok3 {
+					continue
+				}
+				removed := wPrevSeg + wSegNext
+
+				bestDelta := -1e-9
+				bestJ := -1
+
+				for j := 0; j < size-1; j++ {
+					if j >= i-1 && j < i+segLen {
+						continue // inside (or adjacent to) the segment itself
+					}
+
+					a := path[j]
+					b := path[j+1]
+
+					wab, okAB := edgeWeight(graph, closure, a, b)
+					waSeg, okASeg := edgeWeight(graph, closure, a, segStart)
+					wSegB, okSegB := edgeWeight(graph, closure, segEnd, b)
+					if !okAB || !okASeg || !okSegB {
+						continue
+					}
+
+					delta := (wBridge + waSeg + wSegB) - (removed + wab)
+					if delta < bestDelta {
+						bestDelta = delta
+						bestJ = j
+					}
+				}
+
+				if bestJ >= 0 {
+					path = relocateSegment(path, i, segLen, bestJ)
+					currentCost += bestDelta
+					improved = true
+					size = len(path)
+				}
+			}
+		}
+	}
+
+	return path, currentCost
+}
+
+// relocateSegment removes path[i:i+segLen] and reinserts it immediately
+// after index j (an index into the original path, outside the segment).
+func relocateSegment(path []int, i, segLen, j int) []int {
+	segment := append([]int(nil), path[i:i+segLen]...)
+
+	rest := make([]int, 0, len(path)-segLen)
+	rest = append(rest, path[:i]...)
+	rest = append(rest, path[i+segLen:]...)
+
+	// j indexes the original path; shift it to account for the segment
+	// already removed from in front of it. Callers only ever pass a j
+	// strictly outside [i-1, i+segLen), so no other adjustment is needed.
+	insertAt := j
+	if j >= i+segLen {
+		insertAt = j - segLen
+	}
+
+	result := make([]int, 0, len(path))
+	result = append(result, rest[:insertAt+1]...)
+	result = append(result, segment...)
+	result = append(result, rest[insertAt+1:]...)
+	return result
+}
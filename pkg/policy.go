@@ -0,0 +1,199 @@
+package tsp_solver
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+
+	"github.com/ivynya/EEC289Q/pkg/allpairs"
+	"github.com/ivynya/EEC289Q/pkg/pqueue"
+)
+
+// NextNodePolicy selects which randomized tour-construction strategy a
+// worker uses to build its initial tour, so the strategies can be swapped
+// and benchmarked head-to-head via the -policy flag.
+type NextNodePolicy interface {
+	construct(startNode, numNodes int, graph *Graph, rng *rand.Rand, closure *allpairs.MetricClosure) ([]int, float64, bool)
+}
+
+// greedyPolicy builds a tour with randomizedNearestNeighbor, considering
+// the K nearest unvisited candidates of the current node at each step.
+type greedyPolicy struct{ K int }
+
+func (p greedyPolicy) construct(startNode, numNodes int, graph *Graph, rng *rand.Rand, closure *allpairs.MetricClosure) ([]int, float64, bool) {
+	return randomizedNearestNeighbor(startNode, numNodes, graph, rng, closure, p.K)
+}
+
+// dijkstraPolicy builds a tour with randomizedDijkstraNeighbor, running a
+// bounded Dijkstra search from the current node at each step instead of
+// only looking at direct neighbors.
+type dijkstraPolicy struct{ K int }
+
+func (p dijkstraPolicy) construct(startNode, numNodes int, graph *Graph, rng *rand.Rand, closure *allpairs.MetricClosure) ([]int, float64, bool) {
+	return randomizedDijkstraNeighbor(startNode, numNodes, graph, rng, closure, p.K)
+}
+
+// NewPolicy builds the NextNodePolicy named by name ("greedy" or
+// "dijkstra", selected via -policy), each considering k candidates per step.
+func NewPolicy(name string, k int) (NextNodePolicy, error) {
+	switch name {
+	case "", "greedy":
+		return greedyPolicy{K: k}, nil
+	case "dijkstra":
+		return dijkstraPolicy{K: k}, nil
+	default:
+		return nil, fmt.Errorf("unknown policy %q (want greedy or dijkstra)", name)
+	}
+}
+
+// nearestUnvisited returns up to k nearest unvisited neighbors of current,
+// computed with a bounded max-heap (pkg/pqueue) in O(deg log k) rather
+// than an O(deg*k) worst-of-topK scan. If closure is non-nil, every other
+// unvisited node is also considered, priced the same way the rest of the
+// solver prices a pair (edgeWeight: a direct edge, where present, over
+// its closure distance), so the accumulated tour cost matches what the
+// emitted tour is actually charged elsewhere.
+func nearestUnvisited(current int, graph *Graph, visited map[int]bool, closure *allpairs.MetricClosure, k int) []pqueue.Item {
+	pq := &pqueue.PriorityQueue{}
+	heap.Init(pq)
+
+	// Priority is stored negated so the min-heap pops the *worst* (most
+	// distant) of the k candidates kept so far.
+	consider := func(node int, dist float64) {
+		if pq.Len() < k {
+			heap.Push(pq, &pqueue.Item{Value: node, Priority: -dist})
+			return
+		}
+		worst := (*pq)[0]
+		if -worst.Priority > dist {
+			worst.Value = node
+			worst.Priority = -dist
+			pq.Fix(worst)
+		}
+	}
+
+	if closure != nil {
+		for _, n := range graph.nodes {
+			if n == current || visited[n] {
+				continue
+			}
+			w, _ := edgeWeight(graph, closure, current, n)
+			consider(n, w)
+		}
+	} else {
+		for n, w := range graph.edges[current] {
+			if visited[n] {
+				continue
+			}
+			consider(n, w)
+		}
+	}
+
+	items := make([]pqueue.Item, pq.Len())
+	for i, it := range *pq {
+		items[i] = pqueue.Item{Value: it.Value, Priority: -it.Priority}
+	}
+	return items
+}
+
+// randomizedDijkstraNeighbor constructs a path like randomizedNearestNeighbor,
+// but at each step runs a bounded Dijkstra search (boundedDijkstra) from
+// the current vertex until it has popped k unvisited nodes, then samples
+// one weighted by 1/dist -- so on sparse graphs it can look several hops
+// ahead instead of only ever considering direct neighbors.
+func randomizedDijkstraNeighbor(startNode int, numNodes int, graph *Graph, rng *rand.Rand, closure *allpairs.MetricClosure, k int) ([]int, float64, bool) {
+	path := make([]int, 0, numNodes)
+	visited := make(map[int]bool, numNodes)
+
+	current := startNode
+	path = append(path, current)
+	visited[current] = true
+	totalCost := 0.0
+
+	for len(path) < numNodes {
+		candidates := boundedDijkstra(current, graph, visited, k)
+		if len(candidates) == 0 {
+			return nil, 0, false // Dead end
+		}
+
+		choice := sampleByInverseDistance(candidates, rng)
+		current = choice.Value
+		path = append(path, current)
+		visited[current] = true
+		totalCost += choice.Priority
+	}
+
+	// Close the loop
+	first := path[0]
+	last := path[len(path)-1]
+	if w, ok := edgeWeight(graph, closure, last, first); ok {
+		totalCost += w
+	} else {
+		return nil, 0, false // Cannot close loop
+	}
+
+	return path, totalCost, true
+}
+
+// boundedDijkstra runs Dijkstra's algorithm from start over graph's direct
+// edges, using pkg/pqueue as the frontier, and stops as soon as it has
+// popped k unvisited nodes rather than exploring the whole graph. It uses
+// lazy deletion: a node can be pushed more than once (once per edge to it
+// from each finalized node, whether or not that edge shortens its
+// distance), and is only finalized -- its distance fixed,
+// its own edges relaxed -- the first time it is popped, which is always
+// via its cheapest pending entry.
+func boundedDijkstra(start int, graph *Graph, visited map[int]bool, k int) []pqueue.Item {
+	frontier := &pqueue.PriorityQueue{}
+	heap.Init(frontier)
+	heap.Push(frontier, &pqueue.Item{Value: start, Priority: 0})
+
+	finalized := map[int]bool{}
+	var found []pqueue.Item
+
+	for frontier.Len() > 0 && len(found) < k {
+		item := heap.Pop(frontier).(*pqueue.Item)
+		node, dist := item.Value, item.Priority
+		if finalized[node] {
+			continue // a cheaper entry for node was already finalized
+		}
+		finalized[node] = true
+
+		if node != start && !visited[node] {
+			found = append(found, pqueue.Item{Value: node, Priority: dist})
+		}
+
+		for n, w := range graph.edges[node] {
+			if finalized[n] {
+				continue
+			}
+			heap.Push(frontier, &pqueue.Item{Value: n, Priority: dist + w})
+		}
+	}
+
+	return found
+}
+
+// sampleByInverseDistance picks one candidate at random, weighting each by
+// 1/dist so closer candidates are more likely to be chosen.
+func sampleByInverseDistance(candidates []pqueue.Item, rng *rand.Rand) pqueue.Item {
+	weights := make([]float64, len(candidates))
+	total := 0.0
+	for i, c := range candidates {
+		w := 1.0
+		if c.Priority > 0 {
+			w = 1.0 / c.Priority
+		}
+		weights[i] = w
+		total += w
+	}
+
+	pick := rng.Float64() * total
+	for i, w := range weights {
+		if pick < w {
+			return candidates[i]
+		}
+		pick -= w
+	}
+	return candidates[len(candidates)-1]
+}
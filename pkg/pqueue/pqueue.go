@@ -0,0 +1,58 @@
+// Package pqueue implements a generic priority queue on top of
+// container/heap, following the standard library's own priority-queue
+// example but tracking each Item's index so callers can Fix or Remove an
+// already-pushed item in O(log n) instead of only Push/Pop.
+package pqueue
+
+import "container/heap"
+
+// Item is a single element of a PriorityQueue. index is maintained by the
+// queue itself; callers should not set it.
+type Item struct {
+	Value    int
+	Priority float64
+	index    int
+}
+
+// PriorityQueue implements heap.Interface as a min-heap ordered by
+// Item.Priority. Construct one with &PriorityQueue{} and heap.Init.
+type PriorityQueue []*Item
+
+func (pq PriorityQueue) Len() int { return len(pq) }
+
+func (pq PriorityQueue) Less(i, j int) bool {
+	return pq[i].Priority < pq[j].Priority
+}
+
+func (pq PriorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *PriorityQueue) Push(x any) {
+	item := x.(*Item)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *PriorityQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*pq = old[:n-1]
+	return item
+}
+
+// Fix re-establishes the heap ordering for item after its Priority has
+// changed in place.
+func (pq *PriorityQueue) Fix(item *Item) {
+	heap.Fix(pq, item.index)
+}
+
+// Remove removes item from the queue, wherever it currently sits.
+func (pq *PriorityQueue) Remove(item *Item) {
+	heap.Remove(pq, item.index)
+}
@@ -0,0 +1,33 @@
+package tsp_solver
+
+import "fmt"
+
+// SeedMode selects which initial tour construction(s) SolveTSP considers
+// before running local search, via the -seed flag.
+type SeedMode int
+
+const (
+	// SeedNN uses only the randomized worker pool (policy.construct).
+	SeedNN SeedMode = iota
+	// SeedChristofides uses only the deterministic Christofides
+	// construction (pkg/christofides), improved once.
+	SeedChristofides
+	// SeedBoth runs the Christofides seed once up front, then also
+	// runs the randomized worker pool as usual.
+	SeedBoth
+)
+
+// ParseSeedMode parses a -seed flag value ("nn", "christofides", or
+// "both"; empty defaults to "nn").
+func ParseSeedMode(s string) (SeedMode, error) {
+	switch s {
+	case "", "nn":
+		return SeedNN, nil
+	case "christofides":
+		return SeedChristofides, nil
+	case "both":
+		return SeedBoth, nil
+	default:
+		return 0, fmt.Errorf("unknown seed mode %q (want nn, christofides, or both)", s)
+	}
+}
@@ -8,14 +8,25 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ivynya/EEC289Q/pkg/allpairs"
+	"github.com/ivynya/EEC289Q/pkg/christofides"
 	"github.com/rs/zerolog/log"
 )
 
 // SolveTSP computes an approximate solution to the Traveling Salesperson Problem.
 // It uses a parallelized approach with randomized Nearest Neighbor initialization
-// followed by 2-Opt local search optimization.
+// (or randomizedDijkstraNeighbor, per policy) followed by local search
+// (2-Opt, Or-opt and Lin-Kernighan passes, see improve). Per seedMode, a deterministic Christofides tour (see
+// pkg/christofides) can additionally seed, or entirely replace, the
+// randomized worker pool.
 // The function targets a runtime of approximately 1 minute.
-func SolveTSP(graph *Graph, maxCPU, maxSeconds int) ([]int, float64, int) {
+//
+// When graph is not complete, a metric closure is computed once up front
+// (see pkg/allpairs) so both phases can still treat it as one: 2-Opt falls
+// back to closure distances for missing edges, and the returned tour is
+// post-expanded by splicing in the real graph path between consecutive
+// tour nodes that have no direct edge.
+func SolveTSP(graph *Graph, maxCPU, maxSeconds int, policy NextNodePolicy, seedMode SeedMode) ([]int, float64, int) {
 	nodeCount := graph.NodeCount()
 	if nodeCount == 0 {
 		return []int{}, 0.0, 0
@@ -24,6 +35,12 @@ func SolveTSP(graph *Graph, maxCPU, maxSeconds int) ([]int, float64, int) {
 		return graph.nodes, 0.0, 1
 	}
 
+	var closure *allpairs.MetricClosure
+	if !graph.IsComplete() {
+		log.Info().Msg("Graph is not complete; computing metric closure via Floyd-Warshall")
+		closure = allpairs.Build(graph)
+	}
+
 	// global best solution tracking
 	var bestPath []int
 	var totalCycles int
@@ -35,6 +52,26 @@ func SolveTSP(graph *Graph, maxCPU, maxSeconds int) ([]int, float64, int) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	// Christofides is deterministic, so running it more than once can
+	// never find a different tour: seed the global best with it once,
+	// ahead of the randomized worker pool below.
+	if seedMode == SeedChristofides || seedMode == SeedBoth {
+		path, cost := christofides.Build(graph, closure)
+		cost = tourCost(path, graph, closure)
+		path, cost = improve(path, cost, graph, ctx, closure)
+		totalCycles++
+		bestPath, bestCost = path, cost
+		log.Debug().Msgf("Christofides seed improved to cost: %.4f", cost)
+	}
+
+	if seedMode == SeedChristofides {
+		if closure != nil {
+			bestPath = expandPath(bestPath, graph, closure)
+		}
+		bestCost = pathCost(bestPath, graph)
+		return bestPath, bestCost, totalCycles
+	}
+
 	numWorkers := min(runtime.NumCPU(), maxCPU)
 	var wg sync.WaitGroup
 
@@ -50,12 +87,22 @@ func SolveTSP(graph *Graph, maxCPU, maxSeconds int) ([]int, float64, int) {
 			default:
 				startNode := graph.nodes[rng.Intn(nodeCount)]
 
-				path, cost, ok := randomizedNearestNeighbor(startNode, nodeCount, graph, rng)
+				path, _, ok := policy.construct(startNode, nodeCount, graph, rng, closure)
 				// if failed to find a valid tour (e.g. disconnected graph), retry
 				if !ok {
 					continue
 				}
-				path, cost = twoOpt(path, cost, graph, ctx)
+
+				// Policies are free to track their own cost as they build
+				// (e.g. dijkstraPolicy's step distances come from a
+				// shortest-path search, which can be cheaper than the
+				// single direct edge the emitted path actually walks for
+				// that step on a non-metric graph). Re-price the tour they
+				// handed back via edgeWeight before optimizing, so every
+				// policy's output enters improve() -- and the bestCost
+				// comparison below -- on the same footing.
+				cost := tourCost(path, graph, closure)
+				path, cost = improve(path, cost, graph, ctx, closure)
 
 				mu.Lock()
 				totalCycles++
@@ -80,12 +127,42 @@ func SolveTSP(graph *Graph, maxCPU, maxSeconds int) ([]int, float64, int) {
 	if bestPath == nil {
 		return []int{}, 0.0, totalCycles
 	}
+	if closure != nil {
+		bestPath = expandPath(bestPath, graph, closure)
+	}
+	bestCost = pathCost(bestPath, graph)
 	return bestPath, bestCost, totalCycles
 }
 
+// improve cycles the local search passes (2-Opt, Or-opt, 2-Opt,
+// Lin-Kernighan) over path until a full cycle fails to improve it, or ctx
+// is done.
+func improve(path []int, cost float64, graph *Graph, ctx context.Context, closure *allpairs.MetricClosure) ([]int, float64) {
+	for {
+		select {
+		case <-ctx.Done():
+			return path, cost
+		default:
+		}
+
+		before := cost
+		path, cost = twoOpt(path, cost, graph, ctx, closure)
+		path, cost = orOpt(path, cost, graph, ctx, closure)
+		path, cost = twoOpt(path, cost, graph, ctx, closure)
+		path, cost = linKernighan(path, cost, graph, ctx, closure)
+		if cost >= before-1e-9 {
+			return path, cost
+		}
+	}
+}
+
 // randomizedNearestNeighbor constructs a path using a greedy approach with some randomness.
-// At each step, it considers the k nearest unvisited neighbors and picks one randomly.
-func randomizedNearestNeighbor(startNode int, numNodes int, graph *Graph, rng *rand.Rand) ([]int, float64, bool) {
+// At each step, it considers the k nearest unvisited neighbors (via a
+// bounded max-heap, see nearestUnvisited) and picks one randomly.
+// If closure is non-nil (the graph is not complete), distances to unvisited
+// nodes with no direct edge are taken from the metric closure instead of
+// treating them as unreachable.
+func randomizedNearestNeighbor(startNode int, numNodes int, graph *Graph, rng *rand.Rand, closure *allpairs.MetricClosure, k int) ([]int, float64, bool) {
 	path := make([]int, 0, numNodes)
 	visited := make(map[int]bool, numNodes)
 
@@ -94,58 +171,25 @@ func randomizedNearestNeighbor(startNode int, numNodes int, graph *Graph, rng *r
 	visited[current] = true
 	totalCost := 0.0
 
-	type candidate struct {
-		node int
-		dist float64
-	}
-
 	for len(path) < numNodes {
-		neighbors := graph.edges[current]
-
-		// Find top 3 nearest unvisited neighbors
-		var topK []candidate
-		k := 3
-
-		for n, w := range neighbors {
-			if visited[n] {
-				continue
-			}
-
-			// Maintain top K smallest distances
-			if len(topK) < k {
-				topK = append(topK, candidate{n, w})
-			} else {
-				// Find worst in topK
-				maxDistIdx := 0
-				for i := 1; i < len(topK); i++ {
-					if topK[i].dist > topK[maxDistIdx].dist {
-						maxDistIdx = i
-					}
-				}
-
-				if w < topK[maxDistIdx].dist {
-					topK[maxDistIdx] = candidate{n, w}
-				}
-			}
-		}
-
-		if len(topK) == 0 {
+		candidates := nearestUnvisited(current, graph, visited, closure, k)
+		if len(candidates) == 0 {
 			return nil, 0, false // Dead end
 		}
 
-		// Pick random from topK
-		choice := topK[rng.Intn(len(topK))]
+		// Pick random from the k nearest
+		choice := candidates[rng.Intn(len(candidates))]
 
-		current = choice.node
+		current = choice.Value
 		path = append(path, current)
 		visited[current] = true
-		totalCost += choice.dist
+		totalCost += choice.Priority
 	}
 
 	// Close the loop
 	first := path[0]
 	last := path[len(path)-1]
-	if w, ok := graph.edges[last][first]; ok {
+	if w, ok := edgeWeight(graph, closure, last, first); ok {
 		totalCost += w
 	} else {
 		return nil, 0, false // Cannot close loop
@@ -154,8 +198,22 @@ func randomizedNearestNeighbor(startNode int, numNodes int, graph *Graph, rng *r
 	return path, totalCost, true
 }
 
-// twoOpt improves the path by swapping edges.
-func twoOpt(path []int, currentCost float64, graph *Graph, ctx context.Context) ([]int, float64) {
+// edgeWeight returns the weight of edge (u, v), preferring a direct graph
+// edge and falling back to the metric closure (if any) for missing edges.
+func edgeWeight(graph *Graph, closure *allpairs.MetricClosure, u, v int) (float64, bool) {
+	if w, ok := graph.edges[u][v]; ok {
+		return w, true
+	}
+	if closure != nil {
+		return closure.Dist(u, v), true
+	}
+	return 0, false
+}
+
+// twoOpt improves the path by swapping edges. When closure is non-nil,
+// edges missing from graph are costed via the metric closure rather than
+// skipped, so the optimization still runs over a complete metric.
+func twoOpt(path []int, currentCost float64, graph *Graph, ctx context.Context, closure *allpairs.MetricClosure) ([]int, float64) {
 	size := len(path)
 	improved := true
 
@@ -179,11 +237,11 @@ func twoOpt(path []int, currentCost float64, graph *Graph, ctx context.Context)
 				u2 := path[j]
 				v2 := path[(j+1)%size]
 
-				w1 := graph.edges[u1][v1]
-				w2 := graph.edges[u2][v2]
+				w1, _ := edgeWeight(graph, closure, u1, v1)
+				w2, _ := edgeWeight(graph, closure, u2, v2)
 
-				wNew1, ok1 := graph.edges[u1][u2]
-				wNew2, ok2 := graph.edges[v1][v2]
+				wNew1, ok1 := edgeWeight(graph, closure, u1, u2)
+				wNew2, ok2 := edgeWeight(graph, closure, v1, v2)
 
 				if ok1 && ok2 {
 					delta := (wNew1 + wNew2) - (w1 + w2)
@@ -199,6 +257,68 @@ func twoOpt(path []int, currentCost float64, graph *Graph, ctx context.Context)
 	return path, currentCost
 }
 
+// expandPath splices the real graph path between consecutive tour nodes
+// that have no direct edge, turning a tour optimized over the metric
+// closure into a walk that only traverses edges that exist in graph.
+func expandPath(path []int, graph *Graph, closure *allpairs.MetricClosure) []int {
+	size := len(path)
+	expanded := make([]int, 0, size)
+
+	for i, node := range path {
+		expanded = append(expanded, node)
+
+		next := path[(i+1)%size]
+		if _, ok := graph.edges[node][next]; ok {
+			continue
+		}
+
+		// via includes next itself; drop it so the following loop
+		// iteration (which appends next as its own node) doesn't
+		// duplicate it.
+		via := closure.Path(node, next)
+		if len(via) > 0 {
+			expanded = append(expanded, via[:len(via)-1]...)
+		}
+	}
+
+	return expanded
+}
+
+// tourCost sums edgeWeight between every consecutive pair in the closed
+// tour path -- the same metric twoOpt/orOpt/linKernighan price their
+// deltas against. Unlike pathCost, it tolerates a pair with no direct
+// graph edge (falling back to the metric closure), so it can re-price a
+// tour immediately after construction and before expandPath has spliced
+// in real intermediate nodes.
+func tourCost(path []int, graph *Graph, closure *allpairs.MetricClosure) float64 {
+	total := 0.0
+	for i, node := range path {
+		next := path[(i+1)%len(path)]
+		if w, ok := edgeWeight(graph, closure, node, next); ok {
+			total += w
+		}
+	}
+	return total
+}
+
+// pathCost sums the real graph edge weight between every consecutive pair
+// in the closed tour path. It requires every consecutive pair to be
+// directly connected in graph -- true of a complete graph's tour as-is,
+// and of an incomplete graph's tour once expandPath has spliced in the
+// real intermediate nodes. SolveTSP always reports this, rather than the
+// running total accumulated during construction and local search, since
+// that total can be charged at a cheaper multi-hop distance (closure, or
+// a policy's own shortest-path heuristic) than the direct edge the
+// returned path actually ends up walking.
+func pathCost(path []int, graph *Graph) float64 {
+	total := 0.0
+	for i, node := range path {
+		next := path[(i+1)%len(path)]
+		total += graph.edges[node][next]
+	}
+	return total
+}
+
 func reverse(path []int, i, j int) {
 	for i < j {
 		path[i], path[j] = path[j], path[i]